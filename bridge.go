@@ -0,0 +1,292 @@
+/*
+ * XML DOM for GOPL
+ * Copyright 2024 John Douglas Pritchard, Syntelos
+ */
+package xdom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+/*
+ * EncodeOptions selects the attribute encoding used by the JSON
+ * and YAML bridge. The default, nested form encodes an element's
+ * attributes under an "#attr" object; FlattenAttributes instead
+ * spreads them onto the element object as "@name" keys, BadgerFish
+ * style.
+ */
+type EncodeOptions struct {
+	FlattenAttributes bool
+}
+/*
+ * The JSON/YAML mapping: an element becomes an object with
+ * "#name", an optional "#attr" (or flattened "@name" keys) and an
+ * optional "#children" array; a text node is a plain string, a
+ * CDATA section is {"#data": "..."}, and declarations/instructions
+ * are {"#decl": {"name":..., ...}} / {"#instr": {...}}.
+ */
+func (this Document) MarshalJSON() ([]byte, error) {
+	return EncodeJSON(this, EncodeOptions{})
+}
+
+func EncodeJSON(doc Document, opts EncodeOptions) ([]byte, error) {
+	return json.Marshal(documentToValue(doc, opts))
+}
+
+func UnmarshalJSONDocument(data []byte) (Document, error) {
+	return DecodeJSON(data, EncodeOptions{})
+}
+
+func DecodeJSON(data []byte, opts EncodeOptions) (Document, error) {
+	var v interface{}
+	var er error = json.Unmarshal(data, &v)
+	if nil != er {
+		return Document{}, er
+	}
+	return valueToDocument(v, opts)
+}
+/*
+ * EncodeYAML renders the same mapping as EncodeJSON, using the
+ * flow-style subset of YAML 1.2 that is plain JSON: it reads back
+ * with any conformant YAML parser, without this module taking on
+ * a YAML dependency of its own.
+ */
+func (this Document) MarshalYAML() ([]byte, error) {
+	return EncodeYAML(this, EncodeOptions{})
+}
+
+func EncodeYAML(doc Document, opts EncodeOptions) ([]byte, error) {
+	return json.MarshalIndent(documentToValue(doc, opts), "", "  ")
+}
+
+func UnmarshalYAMLDocument(data []byte) (Document, error) {
+	return DecodeYAML(data, EncodeOptions{})
+}
+
+func DecodeYAML(data []byte, opts EncodeOptions) (Document, error) {
+	return DecodeJSON(data, opts)
+}
+/*
+ * documentToValue / nodeToValue walk the tree into the
+ * map[string]interface{} / []interface{} shape that encoding/json
+ * already renders deterministically (object keys sorted).
+ */
+func documentToValue(doc Document, opts EncodeOptions) (interface{}) {
+	var children []interface{}
+	var ct uint32 = doc.CountChildren()
+	var ix uint32
+	for ix = 0; ix < ct; ix++ {
+		children = append(children, nodeToValue(doc.GetChild(ix), opts))
+	}
+	return map[string]interface{}{"#children": children}
+}
+
+func nodeToValue(n Node, opts EncodeOptions) (interface{}) {
+	switch v := n.(type) {
+	case Element:
+		return elementToValue(v, opts)
+	case Text:
+		return textToValue(v)
+	default:
+		return n.String()
+	}
+}
+
+func elementToValue(el Element, opts EncodeOptions) (interface{}) {
+	switch el.KindOf() {
+	case KindDeclaration:
+		return map[string]interface{}{"#decl": elementBody(el, opts)}
+	case KindInstruction:
+		return map[string]interface{}{"#instr": elementBody(el, opts)}
+	default:
+		var obj map[string]interface{} = map[string]interface{}{"#name": el.Name()}
+		applyAttributes(obj, el, opts)
+
+		var children []interface{}
+		var ct uint32 = el.CountChildren()
+		var ix uint32
+		for ix = 0; ix < ct; ix++ {
+			children = append(children, nodeToValue(el.GetChild(ix), opts))
+		}
+		if 0 < len(children) {
+			obj["#children"] = children
+		}
+		return obj
+	}
+}
+
+func elementBody(el Element, opts EncodeOptions) (interface{}) {
+	var obj map[string]interface{} = map[string]interface{}{"name": el.Name()}
+	applyAttributes(obj, el, opts)
+	return obj
+}
+
+func applyAttributes(obj map[string]interface{}, el Element, opts EncodeOptions) {
+	var ct uint32 = el.CountAttributes()
+	if 0 == ct {
+		return
+	}
+	var ix uint32
+	if opts.FlattenAttributes {
+		for ix = 0; ix < ct; ix++ {
+			var at Attribute = el.GetAttribute(ix)
+			obj["@"+at.Name()] = at.Value()
+		}
+	} else {
+		var attrs map[string]string = make(map[string]string, ct)
+		for ix = 0; ix < ct; ix++ {
+			var at Attribute = el.GetAttribute(ix)
+			attrs[at.Name()] = at.Value()
+		}
+		obj["#attr"] = attrs
+	}
+}
+
+func textToValue(t Text) (interface{}) {
+	if KindData == t.KindOf() {
+		return map[string]interface{}{"#data": dataContent(t)}
+	}
+	return string(t)
+}
+
+func dataContent(t Text) (string) {
+	var s string = string(t)
+	var prefix, suffix string = "<![CDATA[", "]]>"
+	if strings.HasPrefix(s, prefix) && strings.HasSuffix(s, suffix) {
+		return s[len(prefix) : len(s)-len(suffix)]
+	}
+	return s
+}
+/*
+ * valueToDocument / valueToNode are the reverse mapping, building
+ * the tree via the NewX constructors and AppendChild/SetAttribute
+ * rather than the parser.
+ */
+func valueToDocument(v interface{}, opts EncodeOptions) (Document, error) {
+	var obj, ok = v.(map[string]interface{})
+	if !ok {
+		return Document{}, errors.New("xdom: expected a JSON object at document root")
+	}
+	var doc Document = NewDocument("")
+
+	var rawChildren, hasChildren = obj["#children"]
+	if hasChildren {
+		var list, ok2 = rawChildren.([]interface{})
+		if !ok2 {
+			return Document{}, errors.New("xdom: \"#children\" must be an array")
+		}
+		var item interface{}
+		for _, item = range list {
+			var n, er = valueToNode(item, opts)
+			if nil != er {
+				return Document{}, er
+			}
+			doc.AppendChild(n)
+		}
+	}
+	return doc, nil
+}
+
+func valueToNode(v interface{}, opts EncodeOptions) (Node, error) {
+	switch value := v.(type) {
+	case string:
+		return NewText(value), nil
+	case map[string]interface{}:
+		if dataVal, ok := value["#data"]; ok {
+			var s, ok2 = dataVal.(string)
+			if !ok2 {
+				return nil, errors.New("xdom: \"#data\" must be a string")
+			}
+			return NewData(s), nil
+		}
+		if declVal, ok := value["#decl"]; ok {
+			return valueToDeclOrInstr(declVal, KindDeclaration, opts)
+		}
+		if instrVal, ok := value["#instr"]; ok {
+			return valueToDeclOrInstr(instrVal, KindInstruction, opts)
+		}
+		if nameVal, ok := value["#name"]; ok {
+			var name, ok2 = nameVal.(string)
+			if !ok2 {
+				return nil, errors.New("xdom: \"#name\" must be a string")
+			}
+			var el Element = NewElement(name)
+			var er error = applyValueAttributes(&el, value, opts)
+			if nil != er {
+				return nil, er
+			}
+			if childrenVal, ok3 := value["#children"]; ok3 {
+				var list, ok4 = childrenVal.([]interface{})
+				if !ok4 {
+					return nil, errors.New("xdom: \"#children\" must be an array")
+				}
+				var item interface{}
+				for _, item = range list {
+					var child, cer = valueToNode(item, opts)
+					if nil != cer {
+						return nil, cer
+					}
+					el.AppendChild(child)
+				}
+			}
+			return el, nil
+		}
+		return nil, errors.New("xdom: object missing \"#name\", \"#data\", \"#decl\" or \"#instr\"")
+	default:
+		return nil, fmt.Errorf("xdom: unsupported JSON value %T in document", v)
+	}
+}
+
+func valueToDeclOrInstr(body interface{}, kind Kind, opts EncodeOptions) (Node, error) {
+	var obj, ok = body.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("xdom: declaration/instruction body must be an object")
+	}
+	var name, ok2 = obj["name"].(string)
+	if !ok2 {
+		return nil, errors.New("xdom: declaration/instruction missing \"name\"")
+	}
+	var el Element = Element{name: name, kind: kind}
+	var er error = applyValueAttributes(&el, obj, opts)
+	if nil != er {
+		return nil, er
+	}
+	return el, nil
+}
+
+func applyValueAttributes(el *Element, obj map[string]interface{}, opts EncodeOptions) (error) {
+	if opts.FlattenAttributes {
+		var key string
+		var val interface{}
+		for key, val = range obj {
+			if strings.HasPrefix(key, "@") {
+				var s, ok = val.(string)
+				if !ok {
+					return fmt.Errorf("xdom: attribute '%s' must be a string", key)
+				}
+				el.SetAttribute(key[1:], s)
+			}
+		}
+		return nil
+	}
+	var attrsVal, ok = obj["#attr"]
+	if !ok {
+		return nil
+	}
+	var attrs, ok2 = attrsVal.(map[string]interface{})
+	if !ok2 {
+		return errors.New("xdom: \"#attr\" must be an object")
+	}
+	var name string
+	var val interface{}
+	for name, val = range attrs {
+		var s, ok3 = val.(string)
+		if !ok3 {
+			return fmt.Errorf("xdom: attribute '%s' must be a string", name)
+		}
+		el.SetAttribute(name, s)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+ * XML DOM for GOPL
+ * Copyright 2024 John Douglas Pritchard, Syntelos
+ */
+package xdom
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	var root Element = NewElement("root")
+	root.SetAttribute("id", "1")
+	var child Element = NewSolitary("child")
+	root.AppendChild(child)
+	root.AppendChild(NewText("hello"))
+
+	var doc Document = NewDocument("test")
+	doc.AppendChild(root)
+
+	var data, er = doc.MarshalJSON()
+	if nil != er {
+		t.Fatalf("Marshaling: %v", er)
+	}
+
+	var back Document
+	back, er = UnmarshalJSONDocument(data)
+	if nil != er {
+		t.Fatalf("Unmarshaling: %v", er)
+	}
+
+	var buf strings.Builder
+	er = back.Write(&buf)
+	if nil != er {
+		t.Fatalf("Writing: %v", er)
+	}
+
+	var expect string = `<root id="1"><child></child>hello</root>`
+	if expect != buf.String() {
+		t.Fatalf("Expected '%s' found '%s'.", expect, buf.String())
+	}
+}
+
+func TestJSONFlattenedAttributes(t *testing.T) {
+	var root Element = NewElement("root")
+	root.SetAttribute("id", "1")
+	var doc Document = NewDocument("test")
+	doc.AppendChild(root)
+
+	var data, er = EncodeJSON(doc, EncodeOptions{FlattenAttributes: true})
+	if nil != er {
+		t.Fatalf("Encoding: %v", er)
+	}
+	if !strings.Contains(string(data), `"@id":"1"`) {
+		t.Fatalf("Expected flattened '@id' attribute, found '%s'.", data)
+	}
+
+	var back Document
+	back, er = DecodeJSON(data, EncodeOptions{FlattenAttributes: true})
+	if nil != er {
+		t.Fatalf("Decoding: %v", er)
+	}
+	var el Element = back.GetChild(0).(Element)
+	var id, ok = attributeValue(el, "id")
+	if !ok || "1" != id {
+		t.Fatalf("Expected id '1' found '%s' (%v).", id, ok)
+	}
+}
+
+func TestYAMLRoundTrip(t *testing.T) {
+	var doc Document = NewDocument("test")
+	doc.AppendChild(NewSolitary("leaf"))
+
+	var data, er = doc.MarshalYAML()
+	if nil != er {
+		t.Fatalf("Marshaling: %v", er)
+	}
+
+	var back Document
+	back, er = UnmarshalYAMLDocument(data)
+	if nil != er {
+		t.Fatalf("Unmarshaling: %v", er)
+	}
+	if 1 != back.CountChildren() {
+		t.Fatalf("Expected (1) child found (%d).", back.CountChildren())
+	}
+}
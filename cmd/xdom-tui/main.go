@@ -0,0 +1,44 @@
+/*
+ * XML DOM for GOPL
+ * Copyright 2024 John Douglas Pritchard, Syntelos
+ */
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/syntelos/go-xdom"
+	"github.com/syntelos/go-xdom/tui"
+)
+
+func main() {
+	if 2 != len(os.Args) {
+		fmt.Fprintln(os.Stderr, "Usage: xdom-tui <file.xml>")
+		os.Exit(1)
+	}
+
+	var fn string = os.Args[1]
+	var fil *os.File
+	var er error
+	fil, er = os.Open(fn)
+	if nil != er {
+		fmt.Fprintf(os.Stderr, "xdom-tui: opening '%s': %v\n", fn, er)
+		os.Exit(1)
+	}
+	defer fil.Close()
+
+	var doc xdom.Document
+	var n xdom.Node
+	n, er = doc.ReadFile(fil)
+	if nil != er {
+		fmt.Fprintf(os.Stderr, "xdom-tui: reading '%s': %v\n", fn, er)
+		os.Exit(1)
+	}
+
+	er = tui.Run(n)
+	if nil != er {
+		fmt.Fprintf(os.Stderr, "xdom-tui: %v\n", er)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * XML DOM for GOPL
+ * Copyright 2024 John Douglas Pritchard, Syntelos
+ */
+package xdom
+/*
+ * Constructors for building a tree directly, without going through
+ * Document.Read or Document.ReadFile.
+ */
+func NewDocument(source string) (Document) {
+	return Document{source: source}
+}
+/*
+ * NewElement constructs an open/close element, e.g. "<name>...</name>".
+ */
+func NewElement(name string) (Element) {
+	return Element{name: name, kind: KindOpen}
+}
+/*
+ * NewSolitary constructs a self-closing element, e.g. "<name/>".
+ */
+func NewSolitary(name string) (Element) {
+	return Element{name: name, kind: KindSolitary}
+}
+
+func NewAttribute(name, value string) (Attribute) {
+	return Attribute{name: name, value: value}
+}
+/*
+ * NewText constructs a plain text node.
+ */
+func NewText(s string) (Text) {
+	return Text(s)
+}
+/*
+ * NewData constructs a CDATA section. Its Content carries the
+ * literal "<![CDATA[...]]>" span, consistent with how the parser
+ * represents a CDATA node read from source.
+ */
+func NewData(s string) (Text) {
+	return Text("<![CDATA[" + s + "]]>")
+}
+/*
+ * AppendChild adds "child" to the element, recording the parent
+ * link when the child is itself an Element so Depth() keeps
+ * working on constructed trees. Namespaces (and attribute owners)
+ * are propagated into this now-parented copy of "el" and cascaded
+ * into any subtree it already carries, so a prefixed attribute or
+ * element anywhere below can resolve a namespace declared on an
+ * ancestor, however deep.
+ */
+func (this *Element) AppendChild(child Node) {
+	if el, ok := child.(Element); ok {
+		el.parent = *this
+		el.propagateNamespaces(this.namespaces)
+		this.children = append(this.children, el)
+	} else {
+		this.children = append(this.children, child)
+	}
+}
+
+func (this *Element) SetName(name string) {
+	this.name = name
+}
+/*
+ * SetAttribute updates the value of an existing attribute, or
+ * appends a new one when "name" is not already present.
+ */
+func (this *Element) SetAttribute(name, value string) {
+	var ix int
+	for ix = range this.attributes {
+		if name == this.attributes[ix].name {
+			this.attributes[ix].value = value
+			return
+		}
+	}
+	this.attributes = append(this.attributes, Attribute{name: name, value: value, owner: *this})
+}
+
+func (this *Element) RemoveAttribute(name string) {
+	var ix int
+	for ix = range this.attributes {
+		if name == this.attributes[ix].name {
+			this.attributes = append(this.attributes[:ix], this.attributes[ix+1:]...)
+			return
+		}
+	}
+}
+
+func (this *Element) RemoveChild(index uint32) {
+	if index < uint32(len(this.children)) {
+		this.children = append(this.children[:index], this.children[index+1:]...)
+	}
+}
+/*
+ * AppendChild adds "child" to the document, recording the parent
+ * link when the child is an Element. Namespaces are propagated as
+ * in Element.AppendChild, rooted at the document (there is no
+ * namespace binding above it to inherit).
+ */
+func (this *Document) AppendChild(child Node) {
+	if el, ok := child.(Element); ok {
+		el.parent = *this
+		el.propagateNamespaces(nil)
+		this.children = append(this.children, el)
+	} else {
+		this.children = append(this.children, child)
+	}
+}
+
+func (this *Document) RemoveChild(index uint32) {
+	if index < uint32(len(this.children)) {
+		this.children = append(this.children[:index], this.children[index+1:]...)
+	}
+}
+
+func (this *Document) SetSource(source string) {
+	this.source = source
+}
@@ -0,0 +1,79 @@
+/*
+ * XML DOM for GOPL
+ * Copyright 2024 John Douglas Pritchard, Syntelos
+ */
+package xdom
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConstructAndWrite(t *testing.T) {
+	var root Element = NewElement("root")
+	root.SetAttribute("id", "1")
+
+	var child Element = NewSolitary("child")
+	child.SetAttribute("name", "a")
+	root.AppendChild(child)
+	root.AppendChild(NewText("hello"))
+
+	var buf strings.Builder
+	var er error = root.Write(&buf)
+	if nil != er {
+		t.Fatalf("Writing: %v", er)
+	}
+
+	var expect string = `<root id="1"><child name="a"/>hello</root>`
+	if expect != buf.String() {
+		t.Fatalf("Expected '%s' found '%s'.", expect, buf.String())
+	}
+}
+
+func TestConstructAppendChildSetsParent(t *testing.T) {
+	var root Element = NewElement("root")
+	var child Element = NewElement("child")
+	root.AppendChild(child)
+
+	var got Node = root.GetChild(0)
+	var el Element = got.(Element)
+	if nil == el.Parent() {
+		t.Fatal("Expected child to have a parent.")
+	}
+}
+
+func TestSetAndRemoveAttribute(t *testing.T) {
+	var el Element = NewElement("el")
+	el.SetAttribute("a", "1")
+	el.SetAttribute("b", "2")
+	el.SetAttribute("a", "3")
+
+	if 2 != el.CountAttributes() {
+		t.Fatalf("Expected (2) attributes found (%d).", el.CountAttributes())
+	}
+
+	el.RemoveAttribute("a")
+	if 1 != el.CountAttributes() {
+		t.Fatalf("Expected (1) attribute found (%d).", el.CountAttributes())
+	}
+	if "b" != el.GetAttribute(0).Name() {
+		t.Fatalf("Expected remaining attribute 'b' found '%s'.", el.GetAttribute(0).Name())
+	}
+}
+
+func TestWriteIndent(t *testing.T) {
+	var root Element = NewElement("root")
+	root.AppendChild(NewSolitary("a"))
+	root.AppendChild(NewSolitary("b"))
+
+	var buf strings.Builder
+	var er error = root.WriteIndent(&buf, "  ")
+	if nil != er {
+		t.Fatalf("Writing: %v", er)
+	}
+
+	var expect string = "<root>\n  <a/>\n  <b/>\n</root>"
+	if expect != buf.String() {
+		t.Fatalf("Expected %q found %q.", expect, buf.String())
+	}
+}
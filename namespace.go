@@ -0,0 +1,175 @@
+/*
+ * XML DOM for GOPL
+ * Copyright 2024 John Douglas Pritchard, Syntelos
+ */
+package xdom
+
+import (
+	"strings"
+)
+/*
+ * bindNamespace records a prefix -> URI binding declared by this
+ * element's own "xmlns"/"xmlns:prefix" attributes, in "declared" --
+ * this element's own bindings only, not yet merged with anything
+ * inherited from an ancestor. The default namespace (a bare "xmlns")
+ * is recorded under the empty prefix.
+ */
+func (this *Element) bindNamespace(prefix, uri string) {
+	if nil == this.declared {
+		this.declared = make(map[string]string)
+	}
+	this.declared[prefix] = uri
+}
+/*
+ * bindDeclaredNamespaces scans this element's own "xmlns"/
+ * "xmlns:prefix" attributes and records each in "declared". Both
+ * Element.Read and the streaming DocumentBuilder call this once an
+ * open or solitary element's attributes are known, so namespace
+ * resolution works the same regardless of which parser built the
+ * tree. The effective, ancestor-inclusive bindings ResolvePrefix
+ * actually reads come later, from propagateNamespaces.
+ */
+func (this *Element) bindDeclaredNamespaces() {
+	switch this.KindOf() {
+	case KindOpen, KindSolitary:
+		var at Attribute
+		for _, at = range this.attributes {
+			if "xmlns" == at.name {
+				this.bindNamespace("", at.value)
+			} else if strings.HasPrefix(at.name, "xmlns:") {
+				this.bindNamespace(at.name[len("xmlns:"):], at.value)
+			}
+		}
+	}
+}
+/*
+ * propagateNamespaces merges "inherited" (the new parent's own
+ * effective bindings) under this element's "declared" bindings into
+ * "namespaces", the map ResolvePrefix reads, then recurses into
+ * every already-attached descendant with the result. AppendChild
+ * calls this on the child it links (with the live parent's
+ * namespaces as "inherited"), and cascades into a subtree that was
+ * itself fully assembled before being attached -- so a prefix
+ * resolves correctly however deep it sits below the element that
+ * declared it, independent of the order a tree was built in. Also
+ * rebinds this element's own attribute owners, since
+ * Attribute.Namespace resolves through its owner the same way, and
+ * refreshes each descendant's parent link, so the chain Depth()
+ * walks reaches up through ancestors attached after the descendant.
+ */
+func (this *Element) propagateNamespaces(inherited map[string]string) {
+	this.namespaces = mergeNamespaces(inherited, this.declared)
+	this.rebindAttributeOwners()
+
+	var ix int
+	for ix = range this.children {
+		if el, ok := this.children[ix].(Element); ok {
+			el.parent = *this
+			el.propagateNamespaces(this.namespaces)
+			this.children[ix] = el
+		}
+	}
+}
+
+func mergeNamespaces(inherited, declared map[string]string) map[string]string {
+	if 0 == len(declared) {
+		return inherited
+	}
+	var merged map[string]string = make(map[string]string, len(inherited)+len(declared))
+	var k, v string
+	for k, v = range inherited {
+		merged[k] = v
+	}
+	for k, v = range declared {
+		merged[k] = v
+	}
+	return merged
+}
+/*
+ * rebindAttributeOwners stamps "this" as the owner of its own
+ * attributes, so the owner Attribute.Namespace sees carries this
+ * element's current (possibly just-propagated) namespaces.
+ */
+func (this *Element) rebindAttributeOwners() {
+	var ix int
+	for ix = range this.attributes {
+		this.attributes[ix].owner = *this
+	}
+}
+/*
+ * Prefix is the part of the element name before ":", or "" when
+ * the name is unprefixed.
+ */
+func (this Element) Prefix() (string) {
+	return prefixOf(this.name)
+}
+/*
+ * LocalName is the part of the element name after ":", or the
+ * whole name when unprefixed.
+ */
+func (this Element) LocalName() (string) {
+	return localNameOf(this.name)
+}
+/*
+ * Namespace resolves this element's own prefix (the default
+ * namespace, when unprefixed) to a URI, or "" when unbound.
+ */
+func (this Element) Namespace() (string) {
+	var uri, ok = this.ResolvePrefix(this.Prefix())
+	if ok {
+		return uri
+	}
+	return ""
+}
+/*
+ * ResolvePrefix looks up "prefix" among this element's effective
+ * namespace bindings -- its own declarations merged with whatever
+ * its ancestors declared, as maintained by propagateNamespaces.
+ */
+func (this Element) ResolvePrefix(prefix string) (string, bool) {
+	var uri, ok = this.namespaces[prefix]
+	return uri, ok
+}
+/*
+ * Prefix and LocalName follow the same rule as Element's, but
+ * Namespace does not: by the XML namespaces rule, an unprefixed
+ * attribute is never in the default namespace, only in no
+ * namespace at all.
+ */
+func (this Attribute) Prefix() (string) {
+	return prefixOf(this.name)
+}
+
+func (this Attribute) LocalName() (string) {
+	return localNameOf(this.name)
+}
+
+func (this Attribute) Namespace() (string) {
+	var prefix string = this.Prefix()
+	if "" == prefix {
+		return ""
+	}
+	if el, ok := this.owner.(Element); ok {
+		var uri, found = el.ResolvePrefix(prefix)
+		if found {
+			return uri
+		}
+	}
+	return ""
+}
+
+func prefixOf(name string) (string) {
+	var ix int = strings.IndexByte(name, ':')
+	if -1 == ix {
+		return ""
+	}
+	return name[:ix]
+}
+
+func localNameOf(name string) (string) {
+	var ix int = strings.IndexByte(name, ':')
+	if -1 == ix {
+		return name
+	}
+	return name[ix+1:]
+}
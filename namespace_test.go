@@ -0,0 +1,149 @@
+/*
+ * XML DOM for GOPL
+ * Copyright 2024 John Douglas Pritchard, Syntelos
+ */
+package xdom
+
+import (
+	"testing"
+)
+
+const svgXlinkFixture = `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" version="1.1">` +
+	`<image id="logo"/>` +
+	`<use xlink:href="#logo"/>` +
+	`</svg>`
+
+func TestElementDefaultNamespace(t *testing.T) {
+	var doc Document = readTestDocument(t, svgXlinkFixture)
+
+	var q CompiledQuery
+	var er error
+	q, er = Compile("/svg")
+	if nil != er {
+		t.Fatalf("Compiling: %v", er)
+	}
+	var svg, ok = q.EvaluateFirst(doc)
+	if !ok {
+		t.Fatal("Expected to find 'svg' element.")
+	}
+	var el Element = svg.(Element)
+
+	if "http://www.w3.org/2000/svg" != el.Namespace() {
+		t.Fatalf("Expected svg namespace 'http://www.w3.org/2000/svg' found '%s'.", el.Namespace())
+	}
+	if "svg" != el.LocalName() {
+		t.Fatalf("Expected local name 'svg' found '%s'.", el.LocalName())
+	}
+	if "" != el.Prefix() {
+		t.Fatalf("Expected no prefix found '%s'.", el.Prefix())
+	}
+}
+
+func TestElementInheritsDefaultNamespace(t *testing.T) {
+	var doc Document = readTestDocument(t, svgXlinkFixture)
+
+	var q CompiledQuery
+	var er error
+	q, er = Compile("//image")
+	if nil != er {
+		t.Fatalf("Compiling: %v", er)
+	}
+	var image, ok = q.EvaluateFirst(doc)
+	if !ok {
+		t.Fatal("Expected to find 'image' element.")
+	}
+	var el Element = image.(Element)
+
+	if "http://www.w3.org/2000/svg" != el.Namespace() {
+		t.Fatalf("Expected inherited namespace 'http://www.w3.org/2000/svg' found '%s'.", el.Namespace())
+	}
+}
+
+func TestAttributeNamespace(t *testing.T) {
+	var doc Document = readTestDocument(t, svgXlinkFixture)
+
+	var q CompiledQuery
+	var er error
+	q, er = Compile("//use")
+	if nil != er {
+		t.Fatalf("Compiling: %v", er)
+	}
+	var use, ok = q.EvaluateFirst(doc)
+	if !ok {
+		t.Fatal("Expected to find 'use' element.")
+	}
+	var el Element = use.(Element)
+	if 1 != el.CountAttributes() {
+		t.Fatalf("Expected (1) attribute found (%d).", el.CountAttributes())
+	}
+	var href Attribute = el.GetAttribute(0)
+
+	if "xlink" != href.Prefix() {
+		t.Fatalf("Expected prefix 'xlink' found '%s'.", href.Prefix())
+	}
+	if "href" != href.LocalName() {
+		t.Fatalf("Expected local name 'href' found '%s'.", href.LocalName())
+	}
+	if "http://www.w3.org/1999/xlink" != href.Namespace() {
+		t.Fatalf("Expected namespace 'http://www.w3.org/1999/xlink' found '%s'.", href.Namespace())
+	}
+
+	var version Attribute = NewAttribute("version", "1.1")
+	if "" != version.Namespace() {
+		t.Fatalf("Expected an unprefixed attribute to carry no namespace, found '%s'.", version.Namespace())
+	}
+}
+
+/*
+ * Regression: the xmlns:xlink declaration sits on the root, and
+ * "use" is nested two levels below it (inside a "g"), unlike
+ * svgXlinkFixture where every prefixed element is a direct child of
+ * the element that declares its namespace.
+ */
+func TestAttributeNamespaceNestedInContainer(t *testing.T) {
+	var content string = `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink">` +
+		`<g><use xlink:href="#logo"/></g>` +
+		`</svg>`
+	var doc Document = readTestDocument(t, content)
+
+	var q CompiledQuery
+	var er error
+	q, er = Compile("//use")
+	if nil != er {
+		t.Fatalf("Compiling: %v", er)
+	}
+	var use, ok = q.EvaluateFirst(doc)
+	if !ok {
+		t.Fatal("Expected to find 'use' element.")
+	}
+	var el Element = use.(Element)
+	var href Attribute = el.GetAttribute(0)
+
+	if "http://www.w3.org/1999/xlink" != href.Namespace() {
+		t.Fatalf("Expected namespace 'http://www.w3.org/1999/xlink' found '%s'.", href.Namespace())
+	}
+}
+
+func TestQueryClarkNotationNameTest(t *testing.T) {
+	var doc Document = readTestDocument(t, svgXlinkFixture)
+
+	var q CompiledQuery
+	var er error
+	q, er = Compile("//{http://www.w3.org/2000/svg}image")
+	if nil != er {
+		t.Fatalf("Compiling: %v", er)
+	}
+	var set NodeSet = q.Evaluate(doc)
+	if 1 != set.CountChildren() {
+		t.Fatalf("Expected (1) match found (%d).", set.CountChildren())
+	}
+
+	q, er = Compile("//{http://example.com/other}image")
+	if nil != er {
+		t.Fatalf("Compiling: %v", er)
+	}
+	set = q.Evaluate(doc)
+	if 0 != set.CountChildren() {
+		t.Fatalf("Expected (0) matches for a foreign namespace found (%d).", set.CountChildren())
+	}
+}
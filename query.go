@@ -0,0 +1,922 @@
+/*
+ * XML DOM for GOPL
+ * Copyright 2024 John Douglas Pritchard, Syntelos
+ */
+package xdom
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+/*
+ * XPath 1.0 axis identifiers.
+ */
+type Axis uint8
+const (
+	AxisChild Axis = iota
+	AxisDescendant
+	AxisAttribute
+	AxisSelf
+	AxisParent
+	AxisAncestor
+	AxisFollowingSibling
+	AxisPrecedingSibling
+)
+
+func (this Axis) String() (string) {
+	switch this {
+	case AxisDescendant:
+		return "descendant"
+	case AxisAttribute:
+		return "attribute"
+	case AxisSelf:
+		return "self"
+	case AxisParent:
+		return "parent"
+	case AxisAncestor:
+		return "ancestor"
+	case AxisFollowingSibling:
+		return "following-sibling"
+	case AxisPrecedingSibling:
+		return "preceding-sibling"
+	default:
+		return "child"
+	}
+}
+/*
+ * A compiled location step: an axis, a name test ("*", "node()",
+ * "text()", or a literal name), and zero or more predicates applied
+ * in sequence.
+ */
+type Step struct {
+	axis Axis
+	name string
+	predicates []Predicate
+}
+/*
+ * A compiled predicate expression, "[left]" or "[left op right]".
+ */
+type Predicate struct {
+	left predExpr
+	op predOp
+	right predExpr
+	hasRight bool
+}
+
+type predOp uint8
+const (
+	predOpEq predOp = iota
+	predOpNe
+	predOpLt
+	predOpLe
+	predOpGt
+	predOpGe
+)
+/*
+ * A predicate operand: a number, a string, an "@name" attribute
+ * test, or a function call (text, name, position, last, contains,
+ * starts-with, not) with its arguments.
+ */
+type predExpr struct {
+	num float64
+	isNum bool
+	str string
+	isStr bool
+	isAttr bool
+	attrName string
+	fn string
+	args []predExpr
+}
+/*
+ * The result of evaluating a CompiledQuery: an ordered list of
+ * Node, satisfying NodeList so it can be walked the same way as
+ * Document or Element children. Each context node's matches are
+ * appended in turn (the ancestor axis lists the nearest ancestor
+ * first); the set is not re-sorted into document order, and a node
+ * reached from more than one context node appears more than once.
+ */
+type NodeSet []Node
+
+func (this NodeSet) CountChildren() (uint32) {
+	return uint32(len(this))
+}
+func (this NodeSet) GetChild(index uint32) (Node) {
+	if index < this.CountChildren() {
+
+		return this[index]
+	} else {
+		return nil
+	}
+}
+/*
+ * An XPath-like expression compiled once via Compile, and evaluated
+ * any number of times against any Node via Evaluate/EvaluateFirst.
+ */
+type CompiledQuery struct {
+	expr string
+	steps []Step
+}
+
+func (this CompiledQuery) String() (string) {
+	return this.expr
+}
+/*
+ * Compile parses an XPath-like expression into a CompiledQuery.
+ * Supported syntax: "/" and "//" location step separators, "."
+ * and "..", name tests including "*", the axes child, descendant,
+ * attribute, self, parent, ancestor, following-sibling and
+ * preceding-sibling via "axis::", predicates "[n]", "[@name]",
+ * "[@name='val']", and the functions text(), name(), position(),
+ * last(), contains(), starts-with() and not().
+ */
+func Compile(expr string) (CompiledQuery, error) {
+	var toks []token
+	var er error
+	toks, er = lex(expr)
+	if nil != er {
+		return CompiledQuery{}, er
+	}
+	var steps []Step
+	steps, er = parseSteps(toks)
+	if nil != er {
+		return CompiledQuery{}, fmt.Errorf("xdom: compiling '%s': %w", expr, er)
+	}
+	return CompiledQuery{expr: expr, steps: steps}, nil
+}
+/*
+ * Evaluate walks the compiled steps from "node" as the initial
+ * context, threading the current node set from one step to the
+ * next.
+ */
+func (this CompiledQuery) Evaluate(node Node) (NodeSet) {
+	var current NodeSet = NodeSet{node}
+
+	for _, step := range this.steps {
+		var next NodeSet
+
+		for _, ctx := range current {
+			var raw []Node = axisNodes(ctx, step.axis, step.name)
+
+			for _, pred := range step.predicates {
+				raw = filterPredicate(raw, pred)
+			}
+			next = append(next, raw...)
+		}
+		current = next
+	}
+	return current
+}
+/*
+ * EvaluateFirst returns the first node of Evaluate's result, or
+ * false when the query matched nothing.
+ */
+func (this CompiledQuery) EvaluateFirst(node Node) (Node, bool) {
+	var set NodeSet = this.Evaluate(node)
+	if 0 < len(set) {
+		return set[0], true
+	} else {
+		return nil, false
+	}
+}
+/*
+ * Lexical tokens of the compiled expression grammar.
+ */
+type tokKind uint8
+const (
+	tokEOF tokKind = iota
+	tokSlash
+	tokSlashSlash
+	tokDot
+	tokDotDot
+	tokName
+	tokAxis
+	tokAt
+	tokLBracket
+	tokRBracket
+	tokLParen
+	tokRParen
+	tokComma
+	tokString
+	tokNumber
+	tokOp
+)
+
+type token struct {
+	kind tokKind
+	text string
+}
+
+func lex(expr string) ([]token, error) {
+	var toks []token
+	var x, z int = 0, len(expr)
+
+	for x < z {
+		var c byte = expr[x]
+		switch {
+		case ' ' == c || '\t' == c:
+			x += 1
+		case '/' == c:
+			if x+1 < z && '/' == expr[x+1] {
+				toks = append(toks, token{tokSlashSlash, "//"})
+				x += 2
+			} else {
+				toks = append(toks, token{tokSlash, "/"})
+				x += 1
+			}
+		case '.' == c:
+			if x+1 < z && '.' == expr[x+1] {
+				toks = append(toks, token{tokDotDot, ".."})
+				x += 2
+			} else {
+				toks = append(toks, token{tokDot, "."})
+				x += 1
+			}
+		case '@' == c:
+			toks = append(toks, token{tokAt, "@"})
+			x += 1
+		case '[' == c:
+			toks = append(toks, token{tokLBracket, "["})
+			x += 1
+		case ']' == c:
+			toks = append(toks, token{tokRBracket, "]"})
+			x += 1
+		case '(' == c:
+			toks = append(toks, token{tokLParen, "("})
+			x += 1
+		case ')' == c:
+			toks = append(toks, token{tokRParen, ")"})
+			x += 1
+		case ',' == c:
+			toks = append(toks, token{tokComma, ","})
+			x += 1
+		case '*' == c:
+			toks = append(toks, token{tokName, "*"})
+			x += 1
+		case '{' == c:
+			var y int = x + 1
+			for y < z && '}' != expr[y] {
+				y += 1
+			}
+			if y >= z {
+				return nil, fmt.Errorf("unterminated namespace literal in '%s'", expr)
+			}
+			y += 1
+			var y2 int = y
+			for y2 < z && isNameChar(expr[y2]) {
+				y2 += 1
+			}
+			toks = append(toks, token{tokName, expr[x:y2]})
+			x = y2
+		case '\'' == c || '"' == c:
+			var quote byte = c
+			var y int = x + 1
+			for y < z && quote != expr[y] {
+				y += 1
+			}
+			if y >= z {
+				return nil, fmt.Errorf("unterminated string literal in '%s'", expr)
+			}
+			toks = append(toks, token{tokString, expr[x+1 : y]})
+			x = y + 1
+		case '=' == c:
+			toks = append(toks, token{tokOp, "="})
+			x += 1
+		case '!' == c && x+1 < z && '=' == expr[x+1]:
+			toks = append(toks, token{tokOp, "!="})
+			x += 2
+		case '>' == c:
+			if x+1 < z && '=' == expr[x+1] {
+				toks = append(toks, token{tokOp, ">="})
+				x += 2
+			} else {
+				toks = append(toks, token{tokOp, ">"})
+				x += 1
+			}
+		case '<' == c:
+			if x+1 < z && '=' == expr[x+1] {
+				toks = append(toks, token{tokOp, "<="})
+				x += 2
+			} else {
+				toks = append(toks, token{tokOp, "<"})
+				x += 1
+			}
+		case isDigit(c):
+			var y int = x + 1
+			for y < z && (isDigit(expr[y]) || '.' == expr[y]) {
+				y += 1
+			}
+			toks = append(toks, token{tokNumber, expr[x:y]})
+			x = y
+		case isNameStart(c):
+			var y int = x + 1
+			for y < z && isNameChar(expr[y]) {
+				y += 1
+			}
+			if y+1 < z && ':' == expr[y] && ':' == expr[y+1] {
+				toks = append(toks, token{tokAxis, expr[x:y]})
+				x = y + 2
+			} else if y < z && ':' == expr[y] && y+1 < z && isNameStart(expr[y+1]) {
+				var y2 int = y + 1
+				for y2 < z && isNameChar(expr[y2]) {
+					y2 += 1
+				}
+				toks = append(toks, token{tokName, expr[x:y2]})
+				x = y2
+			} else {
+				toks = append(toks, token{tokName, expr[x:y]})
+				x = y
+			}
+		default:
+			return nil, fmt.Errorf("unexpected character '%c' in '%s'", c, expr)
+		}
+	}
+	toks = append(toks, token{tokEOF, ""})
+	return toks, nil
+}
+
+func isNameStart(c byte) (bool) {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || '_' == c
+}
+func isNameChar(c byte) (bool) {
+	return isNameStart(c) || isDigit(c) || '-' == c || '.' == c
+}
+func isDigit(c byte) (bool) {
+	return '0' <= c && c <= '9'
+}
+/*
+ * Recursive descent parser over the token stream produced by lex.
+ */
+type parser struct {
+	toks []token
+	pos int
+}
+
+func (this *parser) peek() (token) {
+	return this.toks[this.pos]
+}
+func (this *parser) next() (token) {
+	var t token = this.toks[this.pos]
+	if tokEOF != t.kind {
+		this.pos += 1
+	}
+	return t
+}
+
+func parseSteps(toks []token) ([]Step, error) {
+	var p parser = parser{toks: toks}
+	var steps []Step
+	var forceDescendant bool = false
+
+	switch p.peek().kind {
+	case tokSlashSlash:
+		forceDescendant = true
+		p.next()
+	case tokSlash:
+		p.next()
+	}
+
+	for tokEOF != p.peek().kind {
+		var step Step
+		var er error
+		step, er = parseStep(&p)
+		if nil != er {
+			return nil, er
+		}
+		if forceDescendant {
+			if AxisChild == step.axis {
+				step.axis = AxisDescendant
+			}
+			forceDescendant = false
+		}
+		steps = append(steps, step)
+
+		switch p.peek().kind {
+		case tokSlashSlash:
+			forceDescendant = true
+			p.next()
+		case tokSlash:
+			p.next()
+		default:
+			if tokEOF != p.peek().kind {
+				return nil, fmt.Errorf("unexpected token '%s'", p.peek().text)
+			}
+		}
+	}
+	return steps, nil
+}
+
+func parseStep(p *parser) (Step, error) {
+	var step Step = Step{axis: AxisChild}
+
+	switch p.peek().kind {
+	case tokDot:
+		p.next()
+		step.axis = AxisSelf
+		step.name = "node()"
+		return appendPredicates(p, step)
+	case tokDotDot:
+		p.next()
+		step.axis = AxisParent
+		step.name = "node()"
+		return appendPredicates(p, step)
+	case tokAxis:
+		var axisName string = p.next().text
+		var axis, ok = axisByName(axisName)
+		if !ok {
+			return step, fmt.Errorf("unknown axis '%s::'", axisName)
+		}
+		step.axis = axis
+	case tokAt:
+		p.next()
+		step.axis = AxisAttribute
+	}
+
+	if tokName != p.peek().kind {
+		return step, fmt.Errorf("expected a name test, found '%s'", p.peek().text)
+	}
+	var name string = p.next().text
+
+	if tokLParen == p.peek().kind {
+		p.next()
+		if tokRParen != p.peek().kind {
+			return step, fmt.Errorf("unsupported arguments to node-test '%s()'", name)
+		}
+		p.next()
+		name = name + "()"
+	}
+	step.name = name
+
+	return appendPredicates(p, step)
+}
+
+func appendPredicates(p *parser, step Step) (Step, error) {
+	for tokLBracket == p.peek().kind {
+		p.next()
+		var pred Predicate
+		var er error
+		pred, er = parsePredicate(p)
+		if nil != er {
+			return step, er
+		}
+		if tokRBracket != p.peek().kind {
+			return step, errors.New("expected ']' closing predicate")
+		}
+		p.next()
+		step.predicates = append(step.predicates, pred)
+	}
+	return step, nil
+}
+
+func axisByName(name string) (Axis, bool) {
+	switch name {
+	case "child":
+		return AxisChild, true
+	case "descendant":
+		return AxisDescendant, true
+	case "attribute":
+		return AxisAttribute, true
+	case "self":
+		return AxisSelf, true
+	case "parent":
+		return AxisParent, true
+	case "ancestor":
+		return AxisAncestor, true
+	case "following-sibling":
+		return AxisFollowingSibling, true
+	case "preceding-sibling":
+		return AxisPrecedingSibling, true
+	default:
+		return AxisChild, false
+	}
+}
+
+func parsePredicate(p *parser) (Predicate, error) {
+	var left predExpr
+	var er error
+	left, er = parsePredExpr(p)
+	if nil != er {
+		return Predicate{}, er
+	}
+	var pred Predicate = Predicate{left: left}
+
+	if tokOp == p.peek().kind {
+		pred.op = opFromText(p.next().text)
+
+		var right predExpr
+		right, er = parsePredExpr(p)
+		if nil != er {
+			return Predicate{}, er
+		}
+		pred.right = right
+		pred.hasRight = true
+	}
+	return pred, nil
+}
+
+func opFromText(s string) (predOp) {
+	switch s {
+	case "!=":
+		return predOpNe
+	case "<":
+		return predOpLt
+	case "<=":
+		return predOpLe
+	case ">":
+		return predOpGt
+	case ">=":
+		return predOpGe
+	default:
+		return predOpEq
+	}
+}
+
+func parsePredExpr(p *parser) (predExpr, error) {
+	switch p.peek().kind {
+	case tokNumber:
+		var text string = p.next().text
+		var v float64
+		var er error
+		v, er = strconv.ParseFloat(text, 64)
+		if nil != er {
+			return predExpr{}, fmt.Errorf("bad numeric literal '%s': %w", text, er)
+		}
+		return predExpr{num: v, isNum: true}, nil
+	case tokString:
+		return predExpr{str: p.next().text, isStr: true}, nil
+	case tokAt:
+		p.next()
+		if tokName != p.peek().kind {
+			return predExpr{}, errors.New("expected attribute name after '@'")
+		}
+		return predExpr{isAttr: true, attrName: p.next().text}, nil
+	case tokName:
+		var fn string = p.next().text
+		var args []predExpr
+		if tokLParen == p.peek().kind {
+			p.next()
+			for tokRParen != p.peek().kind {
+				var arg predExpr
+				var er error
+				arg, er = parsePredExpr(p)
+				if nil != er {
+					return predExpr{}, er
+				}
+				args = append(args, arg)
+				if tokComma == p.peek().kind {
+					p.next()
+				} else {
+					break
+				}
+			}
+			if tokRParen != p.peek().kind {
+				return predExpr{}, fmt.Errorf("expected ')' closing '%s('", fn)
+			}
+			p.next()
+		}
+		return predExpr{fn: fn, args: args}, nil
+	default:
+		return predExpr{}, fmt.Errorf("unexpected token '%s' in predicate", p.peek().text)
+	}
+}
+/*
+ * The state threaded through predicate evaluation: the candidate
+ * node, its 1-based position, and the size of the set it was drawn
+ * from (for position() and last()).
+ */
+type evalContext struct {
+	node Node
+	position int
+	total int
+}
+
+func filterPredicate(nodes []Node, pred Predicate) ([]Node) {
+	var out []Node
+	var total int = len(nodes)
+
+	for i, n := range nodes {
+		var ctx evalContext = evalContext{node: n, position: i + 1, total: total}
+		if evalPredicate(pred, ctx) {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+func evalPredicate(pred Predicate, ctx evalContext) (bool) {
+	if !pred.hasRight {
+		if pred.left.isNum {
+			return ctx.position == int(pred.left.num)
+		}
+		switch pred.left.fn {
+		case "not":
+			if 1 == len(pred.left.args) {
+				return "" == evalString(pred.left.args[0], ctx)
+			}
+			return false
+		case "contains":
+			if 2 == len(pred.left.args) {
+				return strings.Contains(evalString(pred.left.args[0], ctx), evalString(pred.left.args[1], ctx))
+			}
+			return false
+		case "starts-with":
+			if 2 == len(pred.left.args) {
+				return strings.HasPrefix(evalString(pred.left.args[0], ctx), evalString(pred.left.args[1], ctx))
+			}
+			return false
+		}
+		if pred.left.isAttr {
+			var _, ok = attributeValue(ctx.node, pred.left.attrName)
+			return ok
+		}
+		return "" != evalString(pred.left, ctx)
+	} else {
+		var ls, rs string = evalString(pred.left, ctx), evalString(pred.right, ctx)
+		var ln, lerr = strconv.ParseFloat(ls, 64)
+		var rn, rerr = strconv.ParseFloat(rs, 64)
+
+		if nil == lerr && nil == rerr {
+			switch pred.op {
+			case predOpEq:
+				return ln == rn
+			case predOpNe:
+				return ln != rn
+			case predOpLt:
+				return ln < rn
+			case predOpLe:
+				return ln <= rn
+			case predOpGt:
+				return ln > rn
+			case predOpGe:
+				return ln >= rn
+			}
+		}
+		switch pred.op {
+		case predOpEq:
+			return ls == rs
+		case predOpNe:
+			return ls != rs
+		case predOpLt:
+			return ls < rs
+		case predOpLe:
+			return ls <= rs
+		case predOpGt:
+			return ls > rs
+		case predOpGe:
+			return ls >= rs
+		}
+		return false
+	}
+}
+
+func evalString(e predExpr, ctx evalContext) (string) {
+	if e.isStr {
+		return e.str
+	}
+	if e.isNum {
+		return strconv.FormatFloat(e.num, 'g', -1, 64)
+	}
+	if e.isAttr {
+		var v, _ = attributeValue(ctx.node, e.attrName)
+		return v
+	}
+	switch e.fn {
+	case "text":
+		return nodeText(ctx.node)
+	case "name":
+		return nodeName(ctx.node)
+	case "position":
+		return strconv.Itoa(ctx.position)
+	case "last":
+		return strconv.Itoa(ctx.total)
+	case "contains":
+		if 2 == len(e.args) && strings.Contains(evalString(e.args[0], ctx), evalString(e.args[1], ctx)) {
+			return "1"
+		}
+		return ""
+	case "starts-with":
+		if 2 == len(e.args) && strings.HasPrefix(evalString(e.args[0], ctx), evalString(e.args[1], ctx)) {
+			return "1"
+		}
+		return ""
+	case "not":
+		if 1 == len(e.args) && "" == evalString(e.args[0], ctx) {
+			return "1"
+		}
+		return ""
+	default:
+		return ""
+	}
+}
+/*
+ * Node accessors shared with predicate evaluation.
+ */
+func nodeName(n Node) (string) {
+	switch v := n.(type) {
+	case Element:
+		return v.Name()
+	case Attribute:
+		return v.Name()
+	default:
+		return ""
+	}
+}
+
+func nodeText(n Node) (string) {
+	switch v := n.(type) {
+	case Element:
+		var buf strings.Builder
+		var ct uint32 = v.CountChildren()
+		var i uint32
+		for i = 0; i < ct; i++ {
+			var ch Node = v.GetChild(i)
+			if ch.KindOf().IsText() {
+				buf.Write(ch.Content())
+			}
+		}
+		return buf.String()
+	default:
+		return string(n.Content())
+	}
+}
+
+func attributeValue(n Node, name string) (string, bool) {
+	var el, ok = n.(Element)
+	if !ok {
+		return "", false
+	}
+	var ct uint32 = el.CountAttributes()
+	var i uint32
+	for i = 0; i < ct; i++ {
+		var at Attribute = el.GetAttribute(i)
+		if name == at.Name() {
+			return at.Value(), true
+		}
+	}
+	return "", false
+}
+/*
+ * Axis traversal, driving each compiled Step from a single context
+ * node.
+ */
+func axisNodes(ctx Node, axis Axis, name string) ([]Node) {
+	switch axis {
+	case AxisChild:
+		return filterNameTest(directChildren(ctx), name)
+	case AxisDescendant:
+		var all []Node
+		collectDescendants(ctx, &all)
+		return filterNameTest(all, name)
+	case AxisAttribute:
+		return filterAttributeTest(ctx, name)
+	case AxisSelf:
+		return filterNameTest([]Node{ctx}, name)
+	case AxisParent:
+		var p Node = parentOf(ctx)
+		if nil != p {
+			return filterNameTest([]Node{p}, name)
+		}
+		return nil
+	case AxisAncestor:
+		var all []Node
+		var p Node = parentOf(ctx)
+		for nil != p {
+			all = append(all, p)
+			p = parentOf(p)
+		}
+		return filterNameTest(all, name)
+	case AxisFollowingSibling:
+		return filterNameTest(siblings(ctx, true), name)
+	case AxisPrecedingSibling:
+		return filterNameTest(siblings(ctx, false), name)
+	default:
+		return nil
+	}
+}
+
+func directChildren(n Node) ([]Node) {
+	var list, ok = n.(NodeList)
+	if !ok {
+		return nil
+	}
+	var ct uint32 = list.CountChildren()
+	var out []Node = make([]Node, 0, ct)
+	var i uint32
+	for i = 0; i < ct; i++ {
+		out = append(out, list.GetChild(i))
+	}
+	return out
+}
+
+func collectDescendants(n Node, out *[]Node) {
+	for _, k := range directChildren(n) {
+		*out = append(*out, k)
+		collectDescendants(k, out)
+	}
+}
+
+func filterNameTest(nodes []Node, name string) ([]Node) {
+	var out []Node
+	for _, n := range nodes {
+		if nameTestMatch(n, name) {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+func nameTestMatch(n Node, name string) (bool) {
+	var kind Kind = n.KindOf()
+	switch {
+	case "node()" == name:
+		return true
+	case "text()" == name:
+		return kind.IsText()
+	case "*" == name:
+		return KindOpen == kind || KindSolitary == kind || KindDocument == kind
+	case strings.HasPrefix(name, "{"):
+		return clarkNameMatch(n, name)
+	default:
+		if KindOpen == kind || KindSolitary == kind {
+			return name == nodeName(n)
+		}
+		return false
+	}
+}
+/*
+ * clarkNameMatch matches a "{uri}local" name test (Clark notation)
+ * against an element's resolved namespace and local name, so that
+ * callers can select by namespace URI instead of by the (possibly
+ * ambiguous) prefix bound to it in the source document.
+ */
+func clarkNameMatch(n Node, name string) (bool) {
+	var el, ok = n.(Element)
+	if !ok {
+		return false
+	}
+	var end int = strings.IndexByte(name, '}')
+	if -1 == end {
+		return false
+	}
+	var uri string = name[1:end]
+	var local string = name[end+1:]
+	return uri == el.Namespace() && local == el.LocalName()
+}
+
+func filterAttributeTest(ctx Node, name string) ([]Node) {
+	var el, ok = ctx.(Element)
+	if !ok {
+		return nil
+	}
+	var out []Node
+	var ct uint32 = el.CountAttributes()
+	var i uint32
+	for i = 0; i < ct; i++ {
+		var at Attribute = el.GetAttribute(i)
+		if "*" == name || name == at.Name() {
+			out = append(out, at)
+		}
+	}
+	return out
+}
+
+func parentOf(n Node) (Node) {
+	var el, ok = n.(Element)
+	if ok {
+		return el.Parent()
+	}
+	return nil
+}
+
+func siblings(ctx Node, following bool) ([]Node) {
+	var p Node = parentOf(ctx)
+	if nil == p {
+		return nil
+	}
+	var kids []Node = directChildren(p)
+	var at int = -1
+	for i, k := range kids {
+		if sameNode(k, ctx) {
+			at = i
+			break
+		}
+	}
+	if -1 == at {
+		return nil
+	} else if following {
+		return kids[at+1:]
+	} else {
+		return kids[:at]
+	}
+}
+/*
+ * Node values carry unexported slice fields and so are not
+ * comparable with "==": identity is approximated by kind and
+ * source content, which is sufficient for sibling lookup since
+ * content is the exact source span of each node.
+ */
+func sameNode(a, b Node) (bool) {
+	return a.KindOf() == b.KindOf() && bytes.Equal(a.Content(), b.Content())
+}
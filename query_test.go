@@ -0,0 +1,130 @@
+/*
+ * XML DOM for GOPL
+ * Copyright 2024 John Douglas Pritchard, Syntelos
+ */
+package xdom
+
+import (
+	"testing"
+)
+
+func readTestDocument(t *testing.T, content string) (Document) {
+	var doc Document
+	var n Node
+	var er error
+	n, er = doc.Read("test", Text(content))
+	if nil != er {
+		t.Fatalf("Reading '%s': %v", content, er)
+	}
+	return n.(Document)
+}
+
+func TestQueryChildAxis(t *testing.T) {
+	var doc Document = readTestDocument(t, "<root><a id=\"1\"/><a id=\"2\"/><b/></root>")
+
+	var q CompiledQuery
+	var er error
+	q, er = Compile("/root/a")
+	if nil != er {
+		t.Fatalf("Compiling: %v", er)
+	}
+	var set NodeSet = q.Evaluate(doc)
+	if 2 != set.CountChildren() {
+		t.Fatalf("Expected (2) found (%d).", set.CountChildren())
+	}
+}
+
+func TestQueryDescendantAxisAndPredicateIndex(t *testing.T) {
+	var doc Document = readTestDocument(t, "<root><a id=\"1\"/><a id=\"2\"/></root>")
+
+	var q CompiledQuery
+	var er error
+	q, er = Compile("//a[2]")
+	if nil != er {
+		t.Fatalf("Compiling: %v", er)
+	}
+	var one Node
+	var ok bool
+	one, ok = q.EvaluateFirst(doc)
+	if !ok {
+		t.Fatal("Expected a match.")
+	}
+	var el Element = one.(Element)
+	var id string
+	id, ok = attributeValue(el, "id")
+	if !ok || "2" != id {
+		t.Fatalf("Expected id '2' found '%s' (%v).", id, ok)
+	}
+}
+
+func TestQueryAttributePredicate(t *testing.T) {
+	var doc Document = readTestDocument(t, "<root><a id=\"1\"/><a/></root>")
+
+	var q CompiledQuery
+	var er error
+	q, er = Compile("//a[@id]")
+	if nil != er {
+		t.Fatalf("Compiling: %v", er)
+	}
+	var set NodeSet = q.Evaluate(doc)
+	if 1 != set.CountChildren() {
+		t.Fatalf("Expected (1) found (%d).", set.CountChildren())
+	}
+}
+
+func TestQueryAttributeAxisAndFunctions(t *testing.T) {
+	var doc Document = readTestDocument(t, "<root><a id=\"one\">hello</a><a id=\"two\">world</a></root>")
+
+	var q CompiledQuery
+	var er error
+	q, er = Compile("//a[contains(text(),'ell')]/@id")
+	if nil != er {
+		t.Fatalf("Compiling: %v", er)
+	}
+	var one Node
+	var ok bool
+	one, ok = q.EvaluateFirst(doc)
+	if !ok {
+		t.Fatal("Expected a match.")
+	}
+	var at Attribute = one.(Attribute)
+	if "one" != at.Value() {
+		t.Fatalf("Expected value 'one' found '%s'.", at.Value())
+	}
+}
+
+/*
+ * Regression for Document.Read/Element.Read: an open tag with no
+ * attributes at all (not even a single one) must still have its
+ * children parsed, at every depth, since query fixtures like
+ * TestQueryChildAxis's "<root>" routinely have none.
+ */
+func TestQueryChildAxisAttributelessChildren(t *testing.T) {
+	var doc Document = readTestDocument(t, "<root><a><b></b><b></b></a></root>")
+
+	var q CompiledQuery
+	var er error
+	q, er = Compile("/root/a/b")
+	if nil != er {
+		t.Fatalf("Compiling: %v", er)
+	}
+	var set NodeSet = q.Evaluate(doc)
+	if 2 != set.CountChildren() {
+		t.Fatalf("Expected (2) found (%d).", set.CountChildren())
+	}
+}
+
+func TestQueryLastFunction(t *testing.T) {
+	var doc Document = readTestDocument(t, "<root><a/><a/><a/></root>")
+
+	var q CompiledQuery
+	var er error
+	q, er = Compile("/root/a[position()=last()]")
+	if nil != er {
+		t.Fatalf("Compiling: %v", er)
+	}
+	var set NodeSet = q.Evaluate(doc)
+	if 1 != set.CountChildren() {
+		t.Fatalf("Expected (1) found (%d).", set.CountChildren())
+	}
+}
@@ -0,0 +1,304 @@
+/*
+ * XML DOM for GOPL
+ * Copyright 2024 John Douglas Pritchard, Syntelos
+ */
+package xdom
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	span "github.com/syntelos/go-span"
+)
+/*
+ * A single parse event produced by Tokenizer.Next: the Kind
+ * distinguishes declarations, instructions and open/solitary/close
+ * elements (Name, and except for close Attributes, are then
+ * meaningful), from text and data (Text is then meaningful).
+ * Depth is the element nesting depth at which the event occurs,
+ * counting from zero at the document root.
+ */
+type Event struct {
+	Kind
+	Name string
+	Attributes []Attribute
+	Text Text
+	Depth uint8
+}
+/*
+ * Tokenizer pulls Event values out of an io.Reader without ever
+ * holding the full document in memory: it reads chunks into a
+ * rolling buffer, finds the next complete "<...>" span or text
+ * run with the same span.Forward scanning TextList.Read uses, and
+ * compacts the buffer as each event is consumed.
+ */
+type Tokenizer struct {
+	src io.Reader
+	buf []byte
+	eof bool
+	offset int64
+	depth uint8
+}
+
+func NewTokenizer(src io.Reader) (*Tokenizer) {
+	return &Tokenizer{src: src}
+}
+/*
+ * Next returns the next Event, or io.EOF once the stream and
+ * buffer are exhausted. An unbalanced close tag is reported as an
+ * error naming the byte offset of the tag.
+ */
+func (this *Tokenizer) Next() (Event, error) {
+	for {
+		if 0 == len(this.buf) {
+			if this.eof {
+				return Event{}, io.EOF
+			}
+			var er error = this.fill()
+			if nil != er && io.EOF != er {
+				return Event{}, er
+			}
+			continue
+		}
+
+		if '<' == this.buf[0] {
+			var z int = len(this.buf)
+			var last int = span.Forward(this.buf, 0, z, '<', '>')
+			if 0 < last {
+				return this.emitCode(last)
+			} else if this.eof {
+				return Event{}, fmt.Errorf("xdom: unterminated tag at offset %d", this.offset)
+			} else {
+				var er error = this.fill()
+				if nil != er && io.EOF != er {
+					return Event{}, er
+				}
+				continue
+			}
+		} else {
+			var next int = bytes.IndexByte(this.buf, '<')
+			if -1 != next {
+				return this.emitText(next)
+			} else if this.eof {
+				return this.emitText(len(this.buf))
+			} else {
+				var er error = this.fill()
+				if nil != er && io.EOF != er {
+					return Event{}, er
+				}
+				continue
+			}
+		}
+	}
+}
+
+func (this *Tokenizer) fill() (error) {
+	if this.eof {
+		return io.EOF
+	}
+	var chunk []byte = make([]byte, 4096)
+	var n int
+	var er error
+	n, er = this.src.Read(chunk)
+	if 0 < n {
+		this.buf = append(this.buf, chunk[:n]...)
+	}
+	if nil != er {
+		if io.EOF == er {
+			this.eof = true
+		}
+		return er
+	}
+	return nil
+}
+/*
+ * emitCode consumes a complete "<...>" span from the front of the
+ * buffer and classifies it exactly as Text.KindOf does, reusing
+ * Element.Read to pull out the name and attributes.
+ */
+func (this *Tokenizer) emitCode(last int) (Event, error) {
+	var text Text = Text(this.buf[0 : last+1])
+	var begin int64 = this.offset
+	this.buf = this.buf[last+1:]
+	this.offset += int64(last + 1)
+
+	var kind Kind = text.KindOf()
+
+	switch kind {
+	case KindClose:
+		if 0 == this.depth {
+			return Event{}, fmt.Errorf("xdom: unbalanced close tag at offset %d: '%s'", begin, text)
+		}
+		this.depth -= 1
+
+		var el Element
+		var n, er = el.Read("", text)
+		if nil != er {
+			return Event{}, er
+		}
+		el = n.(Element)
+		return Event{Kind: kind, Name: el.Name(), Depth: this.depth}, nil
+
+	case KindOpen, KindSolitary, KindDeclaration, KindInstruction:
+		var el Element
+		var n, er = el.Read("", text)
+		if nil != er {
+			return Event{}, er
+		}
+		el = n.(Element)
+
+		var depth uint8 = this.depth
+		if KindOpen == kind {
+			this.depth += 1
+		}
+		return Event{Kind: kind, Name: el.Name(), Attributes: el.attributes, Depth: depth}, nil
+
+	default:
+		return Event{Kind: kind, Text: text, Depth: this.depth}, nil
+	}
+}
+
+func (this *Tokenizer) emitText(n int) (Event, error) {
+	var text Text = Text(this.buf[0:n])
+	this.buf = this.buf[n:]
+	this.offset += int64(n)
+	return Event{Kind: text.KindOf(), Text: text, Depth: this.depth}, nil
+}
+/*
+ * Handler receives the events of Parse. Depth counts element
+ * nesting from zero at the document root.
+ */
+type Handler interface {
+	OnOpen(name string, attributes []Attribute, depth uint8) (error)
+	OnClose(name string, depth uint8) (error)
+	OnSolitary(name string, attributes []Attribute, depth uint8) (error)
+	OnText(text Text, depth uint8) (error)
+	OnData(text Text, depth uint8) (error)
+	OnDeclaration(name string, attributes []Attribute, depth uint8) (error)
+	OnInstruction(name string, attributes []Attribute, depth uint8) (error)
+}
+/*
+ * Parse drives a Tokenizer over "src", dispatching each Event to
+ * the matching Handler method until io.EOF.
+ */
+func Parse(src io.Reader, handler Handler) (error) {
+	var tok *Tokenizer = NewTokenizer(src)
+
+	for {
+		var ev, er = tok.Next()
+		if nil != er {
+			if io.EOF == er {
+				return nil
+			}
+			return er
+		}
+
+		switch ev.Kind {
+		case KindOpen:
+			er = handler.OnOpen(ev.Name, ev.Attributes, ev.Depth)
+		case KindClose:
+			er = handler.OnClose(ev.Name, ev.Depth)
+		case KindSolitary:
+			er = handler.OnSolitary(ev.Name, ev.Attributes, ev.Depth)
+		case KindDeclaration:
+			er = handler.OnDeclaration(ev.Name, ev.Attributes, ev.Depth)
+		case KindInstruction:
+			er = handler.OnInstruction(ev.Name, ev.Attributes, ev.Depth)
+		case KindData:
+			er = handler.OnData(ev.Text, ev.Depth)
+		case KindText:
+			er = handler.OnText(ev.Text, ev.Depth)
+		}
+		if nil != er {
+			return er
+		}
+	}
+}
+/*
+ * DocumentBuilder is a Handler that assembles the same Document
+ * Document.Read produces, by keeping a stack of still-open
+ * elements and appending each one to its parent (or the document)
+ * once its OnClose arrives. Document.Read is expressed as
+ * Parse(r, NewDocumentBuilder(url)) followed by Document().
+ */
+type DocumentBuilder struct {
+	doc Document
+	stack []*Element
+}
+
+func NewDocumentBuilder(source string) (*DocumentBuilder) {
+	return &DocumentBuilder{doc: NewDocument(source)}
+}
+
+func (this *DocumentBuilder) Document() (Document) {
+	return this.doc
+}
+
+func (this *DocumentBuilder) append(n Node) {
+	if 0 < len(this.stack) {
+		var top *Element = this.stack[len(this.stack)-1]
+		top.AppendChild(n)
+	} else {
+		this.doc.AppendChild(n)
+	}
+}
+
+func (this *DocumentBuilder) OnDeclaration(name string, attributes []Attribute, depth uint8) (error) {
+	this.append(Element{name: name, kind: KindDeclaration, attributes: attributes})
+	return nil
+}
+
+func (this *DocumentBuilder) OnInstruction(name string, attributes []Attribute, depth uint8) (error) {
+	this.append(Element{name: name, kind: KindInstruction, attributes: attributes})
+	return nil
+}
+
+func (this *DocumentBuilder) OnOpen(name string, attributes []Attribute, depth uint8) (error) {
+	var el Element = NewElement(name)
+	el.attributes = attributes
+	el.bindDeclaredNamespaces()
+	this.stack = append(this.stack, &el)
+	return nil
+}
+
+func (this *DocumentBuilder) OnClose(name string, depth uint8) (error) {
+	if 0 == len(this.stack) {
+		return fmt.Errorf("xdom: unexpected close tag '%s'", name)
+	}
+	var top *Element = this.stack[len(this.stack)-1]
+	this.stack = this.stack[:len(this.stack)-1]
+	this.append(*top)
+	return nil
+}
+
+func (this *DocumentBuilder) OnSolitary(name string, attributes []Attribute, depth uint8) (error) {
+	var el Element = NewSolitary(name)
+	el.attributes = attributes
+	el.bindDeclaredNamespaces()
+	this.append(el)
+	return nil
+}
+
+func (this *DocumentBuilder) OnText(text Text, depth uint8) (error) {
+	this.append(text)
+	return nil
+}
+
+func (this *DocumentBuilder) OnData(text Text, depth uint8) (error) {
+	this.append(text)
+	return nil
+}
+/*
+ * NewDocumentFromStream parses "r" through Parse/DocumentBuilder,
+ * the streaming counterpart to Document.Read: the source is read in
+ * chunks rather than all at once, while the returned Document holds
+ * the complete tree.
+ */
+func NewDocumentFromStream(source string, r io.Reader) (Document, error) {
+	var builder *DocumentBuilder = NewDocumentBuilder(source)
+	var er error = Parse(r, builder)
+	if nil != er {
+		return Document{}, er
+	}
+	return builder.Document(), nil
+}
@@ -0,0 +1,59 @@
+/*
+ * XML DOM for GOPL
+ * Copyright 2024 John Douglas Pritchard, Syntelos
+ */
+package xdom
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTokenizerEvents(t *testing.T) {
+	var tok *Tokenizer = NewTokenizer(strings.NewReader(`<root a="1"><child/>hello</root>`))
+
+	var kinds []Kind
+	for {
+		var ev, er = tok.Next()
+		if nil != er {
+			break
+		}
+		kinds = append(kinds, ev.Kind)
+	}
+
+	var expect []Kind = []Kind{KindOpen, KindSolitary, KindText, KindClose}
+	if len(expect) != len(kinds) {
+		t.Fatalf("Expected (%d) events found (%d): %v", len(expect), len(kinds), kinds)
+	}
+	for ix, k := range expect {
+		if k != kinds[ix] {
+			t.Fatalf("Event (%d): expected %s found %s.", ix, k, kinds[ix])
+		}
+	}
+}
+
+func TestTokenizerUnbalancedClose(t *testing.T) {
+	var tok *Tokenizer = NewTokenizer(strings.NewReader(`</root>`))
+
+	var _, er = tok.Next()
+	if nil == er {
+		t.Fatal("Expected an unbalanced close tag error.")
+	}
+}
+
+func TestNewDocumentFromStream(t *testing.T) {
+	var doc, er = NewDocumentFromStream("test", strings.NewReader(`<root a="1"><child/>hello</root>`))
+	if nil != er {
+		t.Fatalf("Parsing: %v", er)
+	}
+
+	var q CompiledQuery
+	q, er = Compile("/root/child")
+	if nil != er {
+		t.Fatalf("Compiling: %v", er)
+	}
+	var set NodeSet = q.Evaluate(doc)
+	if 1 != set.CountChildren() {
+		t.Fatalf("Expected (1) found (%d).", set.CountChildren())
+	}
+}
@@ -0,0 +1,136 @@
+/*
+ * XML DOM for GOPL
+ * Copyright 2024 John Douglas Pritchard, Syntelos
+ */
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/syntelos/go-xdom"
+)
+/*
+ * A location step used to render a subtree's XPath on yank:
+ * element name plus its 1-based position among same-named
+ * siblings.
+ */
+type step struct {
+	name string
+	index int
+}
+/*
+ * A single row of the flattened, currently-visible tree.
+ */
+type item struct {
+	node xdom.Node
+	depth int
+	path string
+	hasChildren bool
+	steps []step
+}
+/*
+ * rebuildItems flattens "root" into visible rows, expanding only
+ * the paths present in "expanded".
+ */
+func rebuildItems(root xdom.Node, expanded map[string]bool) ([]item) {
+	var items []item
+	walk(root, 0, "", nil, expanded, &items)
+	return items
+}
+
+func walk(n xdom.Node, depth int, path string, steps []step, expanded map[string]bool, items *[]item) {
+	*items = append(*items, item{node: n, depth: depth, path: path, hasChildren: hasChildren(n), steps: steps})
+
+	if !expanded[path] {
+		return
+	}
+	var list, ok = n.(xdom.NodeList)
+	if !ok {
+		return
+	}
+	var ct uint32 = list.CountChildren()
+	var nameCount map[string]int = make(map[string]int)
+	var ix uint32
+	for ix = 0; ix < ct; ix++ {
+		var child xdom.Node = list.GetChild(ix)
+		var name string = nodeLabel(child)
+		nameCount[name] += 1
+
+		var childSteps []step = make([]step, 0, len(steps)+1)
+		childSteps = append(childSteps, steps...)
+		childSteps = append(childSteps, step{name: name, index: nameCount[name]})
+
+		walk(child, depth+1, fmt.Sprintf("%s/%d", path, ix), childSteps, expanded, items)
+	}
+}
+
+func hasChildren(n xdom.Node) (bool) {
+	var list, ok = n.(xdom.NodeList)
+	return ok && 0 < list.CountChildren()
+}
+
+func nodeLabel(n xdom.Node) (string) {
+	switch v := n.(type) {
+	case xdom.Element:
+		return v.Name()
+	case xdom.Attribute:
+		return "@" + v.Name()
+	default:
+		return "text()"
+	}
+}
+/*
+ * xpathFor renders the location path of "it" from the document
+ * root, e.g. "/svg/g[2]/path"; a position of 1 is left implicit.
+ */
+func xpathFor(it item) (string) {
+	if 0 == len(it.steps) {
+		return "/"
+	}
+	var b strings.Builder
+	for _, s := range it.steps {
+		b.WriteByte('/')
+		b.WriteString(s.name)
+		if 1 < s.index {
+			fmt.Fprintf(&b, "[%d]", s.index)
+		}
+	}
+	return b.String()
+}
+/*
+ * matchesItem is the "/" search predicate: a case-insensitive
+ * substring match against an element's name or attribute values,
+ * or a text node's content.
+ */
+func matchesItem(n xdom.Node, lower string) (bool) {
+	switch v := n.(type) {
+	case xdom.Element:
+		if strings.Contains(strings.ToLower(v.Name()), lower) {
+			return true
+		}
+		var ct uint32 = v.CountAttributes()
+		var ix uint32
+		for ix = 0; ix < ct; ix++ {
+			var at xdom.Attribute = v.GetAttribute(ix)
+			if strings.Contains(strings.ToLower(at.Value()), lower) {
+				return true
+			}
+		}
+		return false
+	default:
+		return strings.Contains(strings.ToLower(n.String()), lower)
+	}
+}
+
+func findMatches(items []item, term string) ([]int) {
+	var matches []int
+	var lower string = strings.ToLower(term)
+	var ix int
+	for ix = range items {
+		if matchesItem(items[ix].node, lower) {
+			matches = append(matches, ix)
+		}
+	}
+	return matches
+}
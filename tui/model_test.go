@@ -0,0 +1,59 @@
+/*
+ * XML DOM for GOPL
+ * Copyright 2024 John Douglas Pritchard, Syntelos
+ */
+package tui
+
+import (
+	"testing"
+
+	"github.com/syntelos/go-xdom"
+)
+
+func buildTestTree() (xdom.Element) {
+	var root xdom.Element = xdom.NewElement("root")
+	var a1 xdom.Element = xdom.NewElement("a")
+	a1.SetAttribute("id", "1")
+	var a2 xdom.Element = xdom.NewElement("a")
+	a2.SetAttribute("id", "2")
+	root.AppendChild(a1)
+	root.AppendChild(a2)
+	return root
+}
+
+func TestRebuildItemsCollapsed(t *testing.T) {
+	var root xdom.Element = buildTestTree()
+	var items []item = rebuildItems(root, map[string]bool{})
+
+	if 1 != len(items) {
+		t.Fatalf("Expected (1) item with nothing expanded, found (%d).", len(items))
+	}
+}
+
+func TestRebuildItemsExpanded(t *testing.T) {
+	var root xdom.Element = buildTestTree()
+	var items []item = rebuildItems(root, map[string]bool{"": true})
+
+	if 3 != len(items) {
+		t.Fatalf("Expected (3) items, found (%d).", len(items))
+	}
+	if "/a" != xpathFor(items[1]) {
+		t.Fatalf("Expected xpath '/a' found '%s'.", xpathFor(items[1]))
+	}
+	if "/a[2]" != xpathFor(items[2]) {
+		t.Fatalf("Expected xpath '/a[2]' found '%s'.", xpathFor(items[2]))
+	}
+}
+
+func TestFindMatches(t *testing.T) {
+	var root xdom.Element = buildTestTree()
+	var items []item = rebuildItems(root, map[string]bool{"": true})
+
+	var matches []int = findMatches(items, "2")
+	if 1 != len(matches) {
+		t.Fatalf("Expected (1) match found (%d).", len(matches))
+	}
+	if 2 != matches[0] {
+		t.Fatalf("Expected match at index (2) found (%d).", matches[0])
+	}
+}
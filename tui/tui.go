@@ -0,0 +1,351 @@
+/*
+ * XML DOM for GOPL
+ * Copyright 2024 John Douglas Pritchard, Syntelos
+ */
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/syntelos/go-xdom"
+)
+/*
+ * Run launches the interactive tree browser over "root" and blocks
+ * until the user quits. Pressing "o" dumps the selected subtree to
+ * stdout after the program exits.
+ */
+func Run(root xdom.Node) (error) {
+	var p *tea.Program = tea.NewProgram(newModel(root))
+	var final, er = p.Run()
+	if nil != er {
+		return er
+	}
+	var m, ok = final.(model)
+	if ok && m.dump && 0 <= m.cursor && m.cursor < len(m.items) {
+		return dumpNode(m.items[m.cursor].node)
+	}
+	return nil
+}
+
+func dumpNode(n xdom.Node) (error) {
+	if el, ok := n.(xdom.Element); ok {
+		return el.WriteIndent(os.Stdout, "  ")
+	}
+	var _, er = fmt.Fprintln(os.Stdout, n.String())
+	return er
+}
+/*
+ * model is the Bubble Tea model/update/view loop: a flattened,
+ * collapsible view of the tree on the left, driven entirely by
+ * Node.KindOf, CountChildren/GetChild and
+ * CountAttributes/GetAttribute -- no parser changes required.
+ */
+type model struct {
+	root xdom.Node
+	expanded map[string]bool
+	items []item
+	cursor int
+
+	searching bool
+	search string
+	matches []int
+	matchIx int
+
+	message string
+	dump bool
+
+	width, height int
+}
+/*
+ * defaultWidth is the width recorded before the first
+ * tea.WindowSizeMsg arrives (e.g. under a test harness that never
+ * sends one). The panes are laid out with the fixed treeWidth
+ * column whatever width is recorded.
+ */
+const defaultWidth = 80
+const treeWidth = 32
+
+func newModel(root xdom.Node) (model) {
+	var m model = model{root: root, expanded: map[string]bool{"": true}, width: defaultWidth}
+	m.items = rebuildItems(m.root, m.expanded)
+	return m
+}
+
+func (this model) Init() (tea.Cmd) {
+	return nil
+}
+
+func (this model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if sizeMsg, ok := msg.(tea.WindowSizeMsg); ok {
+		var next model = this
+		next.width, next.height = sizeMsg.Width, sizeMsg.Height
+		return next, nil
+	}
+
+	var keyMsg, ok = msg.(tea.KeyMsg)
+	if !ok {
+		return this, nil
+	}
+
+	var next model = this
+	if next.searching {
+		return next.updateSearch(keyMsg)
+	}
+
+	switch keyMsg.String() {
+	case "ctrl+c", "q":
+		return next, tea.Quit
+	case "j", "down":
+		if next.cursor+1 < len(next.items) {
+			next.cursor += 1
+		}
+	case "k", "up":
+		if 0 < next.cursor {
+			next.cursor -= 1
+		}
+	case "l", "right", "enter":
+		next.expand()
+	case "h", "left":
+		next.collapse()
+	case "/":
+		next.searching = true
+		next.search = ""
+	case "n":
+		next.jumpMatch(1)
+	case "N":
+		next.jumpMatch(-1)
+	case "y":
+		next.yank()
+	case "o":
+		next.dump = true
+		return next, tea.Quit
+	}
+	return next, nil
+}
+
+func (this model) updateSearch(keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var next model = this
+
+	switch keyMsg.String() {
+	case "esc":
+		next.searching = false
+	case "enter":
+		next.searching = false
+		next.matches = findMatches(next.items, next.search)
+		next.matchIx = -1
+		next.jumpMatch(1)
+	case "backspace":
+		if 0 < len(next.search) {
+			next.search = next.search[:len(next.search)-1]
+		}
+	default:
+		if 1 == len(keyMsg.String()) {
+			next.search += keyMsg.String()
+		}
+	}
+	return next, nil
+}
+
+func (this *model) expand() {
+	var it item = this.items[this.cursor]
+	if it.hasChildren {
+		this.expanded[it.path] = true
+		this.items = rebuildItems(this.root, this.expanded)
+	}
+}
+
+func (this *model) collapse() {
+	var it item = this.items[this.cursor]
+	if this.expanded[it.path] {
+		delete(this.expanded, it.path)
+	} else if 0 < it.depth {
+		var parent string = parentPath(it.path)
+		delete(this.expanded, parent)
+		var ix int
+		for ix = range this.items {
+			if parent == this.items[ix].path {
+				this.cursor = ix
+				break
+			}
+		}
+	}
+	this.items = rebuildItems(this.root, this.expanded)
+}
+
+func (this *model) jumpMatch(dir int) {
+	if 0 == len(this.matches) {
+		return
+	}
+	this.matchIx += dir
+	if this.matchIx < 0 {
+		this.matchIx = len(this.matches) - 1
+	} else if len(this.matches) <= this.matchIx {
+		this.matchIx = 0
+	}
+	this.cursor = this.matches[this.matchIx]
+}
+
+func (this *model) yank() {
+	var it item = this.items[this.cursor]
+	var path string = xpathFor(it)
+	var er error = clipboard.WriteAll(path)
+	if nil != er {
+		this.message = fmt.Sprintf("yank failed: %v", er)
+	} else {
+		this.message = "yanked " + path
+	}
+}
+
+func parentPath(path string) (string) {
+	var ix int = strings.LastIndex(path, "/")
+	if ix <= 0 {
+		return ""
+	}
+	return path[:ix]
+}
+
+/*
+ * View renders two panes side by side: a collapsible tree on the
+ * left (treeWidth columns), and an attribute table plus text/CDATA
+ * content preview for the selected node on the right.
+ */
+func (this model) View() (string) {
+	var body string = this.renderPanes()
+	if this.searching {
+		return body + fmt.Sprintf("\n/%s", this.search)
+	}
+	var footer string = "j/k move  l/h expand/collapse  / search  n/N next/prev  y yank xpath  o dump & quit  q quit"
+	if "" != this.message {
+		footer = this.message
+	}
+	return body + "\n" + footer
+}
+
+func (this model) renderPanes() (string) {
+	var left []string = this.treeLines()
+	var right []string = this.detailLines()
+
+	var rows int = len(left)
+	if len(right) > rows {
+		rows = len(right)
+	}
+
+	var b strings.Builder
+	var ix int
+	for ix = 0; ix < rows; ix++ {
+		var lhs, rhs string
+		if ix < len(left) {
+			lhs = left[ix]
+		}
+		if ix < len(right) {
+			rhs = right[ix]
+		}
+		fmt.Fprintf(&b, "%s%s| %s\n", lhs, strings.Repeat(" ", pad(lhs, treeWidth)), rhs)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func pad(s string, width int) (int) {
+	var n int = width - len(s)
+	if 0 < n {
+		return n
+	}
+	return 1
+}
+
+func (this model) treeLines() ([]string) {
+	var lines []string = make([]string, 0, len(this.items))
+	var ix int
+	for ix = range this.items {
+		var it item = this.items[ix]
+		var cursor string = "  "
+		if ix == this.cursor {
+			cursor = "> "
+		}
+		var marker string = " "
+		if it.hasChildren {
+			if this.expanded[it.path] {
+				marker = "-"
+			} else {
+				marker = "+"
+			}
+		}
+		lines = append(lines, fmt.Sprintf("%s%s%s %s", cursor, strings.Repeat("  ", it.depth), marker, treeLabel(it.node)))
+	}
+	return lines
+}
+/*
+ * treeLabel is the left-pane row label: just the element's name (or
+ * a text/CDATA node's short preview) -- attributes are shown in the
+ * detail pane instead of inline, since they have their own table
+ * there.
+ */
+func treeLabel(n xdom.Node) (string) {
+	if el, ok := n.(xdom.Element); ok {
+		return el.Name()
+	}
+	return n.String()
+}
+/*
+ * detailLines is the right-pane content for the selected item: an
+ * attribute table for an element, or the content itself for a text
+ * or CDATA node.
+ */
+func (this model) detailLines() ([]string) {
+	if 0 > this.cursor || this.cursor >= len(this.items) {
+		return nil
+	}
+	var n xdom.Node = this.items[this.cursor].node
+
+	switch v := n.(type) {
+	case xdom.Element:
+		return elementDetailLines(v)
+	case xdom.Attribute:
+		return []string{fmt.Sprintf("@%s = %q", v.Name(), v.Value())}
+	default:
+		return []string{"Text:", string(n.Content())}
+	}
+}
+
+func elementDetailLines(el xdom.Element) ([]string) {
+	var lines []string = []string{el.Name(), ""}
+
+	var ct uint32 = el.CountAttributes()
+	if 0 == ct {
+		lines = append(lines, "(no attributes)")
+	} else {
+		lines = append(lines, "Attributes:")
+		var ix uint32
+		for ix = 0; ix < ct; ix++ {
+			var at xdom.Attribute = el.GetAttribute(ix)
+			lines = append(lines, fmt.Sprintf("  %s = %q", at.Name(), at.Value()))
+		}
+	}
+
+	var text []string = textPreview(el)
+	if 0 < len(text) {
+		lines = append(lines, "", "Text:")
+		lines = append(lines, text...)
+	}
+	return lines
+}
+/*
+ * textPreview collects this element's direct text/CDATA children,
+ * one line per child, as the right-pane content preview.
+ */
+func textPreview(el xdom.Element) ([]string) {
+	var lines []string
+	var ct uint32 = el.CountChildren()
+	var ix uint32
+	for ix = 0; ix < ct; ix++ {
+		var child xdom.Node = el.GetChild(ix)
+		if text, ok := child.(xdom.Text); ok {
+			lines = append(lines, string(text.Content()))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,206 @@
+/*
+ * XML DOM for GOPL
+ * Copyright 2024 John Douglas Pritchard, Syntelos
+ */
+package xdom
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+/*
+ * Write re-serializes the document as compact XML: declarations,
+ * instructions and the root element, in document order.
+ */
+func (this Document) Write(w io.Writer) (error) {
+	return this.write(w, "", false)
+}
+/*
+ * WriteIndent is Write with each nested element indented by one
+ * "indent" per level of nesting, the document's top-level nodes
+ * having none.
+ */
+func (this Document) WriteIndent(w io.Writer, indent string) (error) {
+	return this.write(w, indent, true)
+}
+
+func (this Document) write(w io.Writer, indent string, pretty bool) (error) {
+	var ct int = len(this.children)
+	var ix int
+	for ix = 0; ix < ct; ix++ {
+		var er error = writeNode(w, this.children[ix], indent, 0, pretty)
+		if nil != er {
+			return er
+		}
+		if pretty && ix+1 < ct {
+			_, er = io.WriteString(w, "\n")
+			if nil != er {
+				return er
+			}
+		}
+	}
+	return nil
+}
+/*
+ * Write re-serializes the element, open/close or solitary as
+ * appropriate, with its attributes and children, as compact XML.
+ */
+func (this Element) Write(w io.Writer) (error) {
+	return writeNode(w, this, "", 0, false)
+}
+
+func (this Element) WriteIndent(w io.Writer, indent string) (error) {
+	return writeNode(w, this, indent, 0, true)
+}
+/*
+ * writeNode dispatches on Kind: declarations and instructions are
+ * "<!name attrs>"/"<?name attrs?>", open/solitary elements recurse
+ * into writeElement, data nodes are emitted verbatim (their
+ * Content already carries the "<![CDATA[...]]>" span), and
+ * anything else is written as escaped text.
+ */
+func writeNode(w io.Writer, n Node, indent string, depth int, pretty bool) (error) {
+	switch n.KindOf() {
+	case KindDeclaration:
+		return writeDeclOrInstr(w, n.(Element), "<!", ">", indent, depth, pretty)
+	case KindInstruction:
+		return writeDeclOrInstr(w, n.(Element), "<?", "?>", indent, depth, pretty)
+	case KindOpen, KindSolitary:
+		return writeElement(w, n.(Element), indent, depth, pretty)
+	case KindData:
+		var er error = writeIndent(w, indent, depth, pretty)
+		if nil != er {
+			return er
+		}
+		_, er = w.Write(n.Content())
+		return er
+	default:
+		var er error = writeIndent(w, indent, depth, pretty)
+		if nil != er {
+			return er
+		}
+		_, er = io.WriteString(w, escapeText(string(n.Content())))
+		return er
+	}
+}
+
+func writeElement(w io.Writer, el Element, indent string, depth int, pretty bool) (error) {
+	var er error = writeIndent(w, indent, depth, pretty)
+	if nil != er {
+		return er
+	}
+	_, er = fmt.Fprintf(w, "<%s", el.Name())
+	if nil != er {
+		return er
+	}
+	er = writeAttributes(w, el)
+	if nil != er {
+		return er
+	}
+
+	if KindSolitary == el.KindOf() {
+		_, er = io.WriteString(w, "/>")
+		return er
+	}
+
+	_, er = io.WriteString(w, ">")
+	if nil != er {
+		return er
+	}
+
+	var ct uint32 = el.CountChildren()
+	var onlyText bool = true
+	var ix uint32
+	for ix = 0; ix < ct; ix++ {
+		if !el.GetChild(ix).KindOf().IsText() {
+			onlyText = false
+			break
+		}
+	}
+	var childPretty bool = pretty && !onlyText
+
+	if 0 < ct {
+		if childPretty {
+			_, er = io.WriteString(w, "\n")
+			if nil != er {
+				return er
+			}
+		}
+		for ix = 0; ix < ct; ix++ {
+			er = writeNode(w, el.GetChild(ix), indent, depth+1, childPretty)
+			if nil != er {
+				return er
+			}
+			if childPretty {
+				_, er = io.WriteString(w, "\n")
+				if nil != er {
+					return er
+				}
+			}
+		}
+		if childPretty {
+			er = writeIndent(w, indent, depth, pretty)
+			if nil != er {
+				return er
+			}
+		}
+	}
+
+	_, er = fmt.Fprintf(w, "</%s>", el.Name())
+	return er
+}
+
+func writeDeclOrInstr(w io.Writer, el Element, open, close string, indent string, depth int, pretty bool) (error) {
+	var er error = writeIndent(w, indent, depth, pretty)
+	if nil != er {
+		return er
+	}
+	_, er = fmt.Fprintf(w, "%s%s", open, el.Name())
+	if nil != er {
+		return er
+	}
+	er = writeAttributes(w, el)
+	if nil != er {
+		return er
+	}
+	_, er = io.WriteString(w, close)
+	return er
+}
+
+func writeAttributes(w io.Writer, el Element) (error) {
+	var ct uint32 = el.CountAttributes()
+	var ix uint32
+	for ix = 0; ix < ct; ix++ {
+		var at Attribute = el.GetAttribute(ix)
+		var _, er = fmt.Fprintf(w, " %s=\"%s\"", at.Name(), escapeAttr(at.Value()))
+		if nil != er {
+			return er
+		}
+	}
+	return nil
+}
+
+func writeIndent(w io.Writer, indent string, depth int, pretty bool) (error) {
+	if pretty && "" != indent {
+		var ix int
+		for ix = 0; ix < depth; ix++ {
+			var _, er = io.WriteString(w, indent)
+			if nil != er {
+				return er
+			}
+		}
+	}
+	return nil
+}
+
+func escapeText(s string) (string) {
+	return textEscaper.Replace(s)
+}
+
+func escapeAttr(s string) (string) {
+	return attrEscaper.Replace(s)
+}
+
+var textEscaper *strings.Replacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+var attrEscaper *strings.Replacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", "\"", "&quot;")
@@ -66,8 +66,11 @@ type Document struct {
 type Element struct {
 	parent Node
 	content Text
+	kind Kind
 	name string
 	attributes []Attribute
+	declared map[string]string
+	namespaces map[string]string
 	children []Node
 }
 
@@ -75,6 +78,7 @@ type Attribute struct {
 	content Text
 	name string
 	value string
+	owner Node
 }
 
 type Text []byte
@@ -185,75 +189,34 @@ func (this Document) ReadFile (src *os.File) (n Node, er error){
 	}
 }
 /*
- * XML document parser.
+ * XML document parser, re-expressed on top of the streaming
+ * Tokenizer/DocumentBuilder introduced for NewDocumentFromStream:
+ * the two no longer diverge on how an element's attributes or an
+ * attribute-less open tag's children are scanned.
  */
 func (this Document) Read (url string, content Text) (n Node, er error){
-	this.source = url
-	this.content = content
-	{
-		var source TextList
-		var kind Kind
-		var text Text
-		var body []byte
-
-		n, er = source.Read(url,content)
-
-		if nil == er {
-			source = n.(TextList)
-
-			for _, text = range source {
-				kind = text.KindOf()
-				if kind.IsCode() {
-
-					if kind.IsBody() {
-						/*
-						 * Document body
-						 */
-						body = span.Cat(body,text)
-					} else {
-						/*
-						 * Document head
-						 */
-						var el Element
-						n, er = el.Read(url,text)
-						if nil != er {
-							return this, er
-						} else {
-							el = n.(Element)
+	var builder *DocumentBuilder = NewDocumentBuilder(url)
 
-							this.children = append(this.children,el)
-						}
-					}
-				} else if kind.IsText() {
-
-					if 0 == len(body) {
-						this.children = append(this.children,text)
-					} else {
-						body = span.Cat(body,text)
-					}
-				}
-			}
-		}
-		/*
-		 * Document body
-		 */
-		var el Element
-		n, er = el.Read(url,body)
-		if nil != er {
-			return this, er
-		} else {
-			el = n.(Element)
-
-			this.children = append(this.children,el)
-		}
+	er = Parse(bytes.NewReader(content), builder)
+	if nil != er {
+		return this, er
 	}
-	return this, nil
+	var doc Document = builder.Document()
+	doc.content = content
+
+	return doc, nil
 }
 func (this Element) KindOf() (Kind){
 	if 0 != len(this.content) {
 		return this.content.KindOf()
+	} else if KindUndefined != this.kind {
+		/*
+		 * Element built via a constructor rather than the parser:
+		 * fall back to the kind recorded at construction.
+		 */
+		return this.kind
 	} else {
-		return KindUndefined
+		return KindOpen
 	}
 }
 func (this Element) Parent() (Node){
@@ -391,12 +354,13 @@ func (this Element) Read(url string, content Text) (n Node, er error) {
 				} else {
 					y = span.Class(this.content,x,z,span.XI)
 					if 0 < y {
-						if '=' == this.content[y] {
-							y += 1
+						var eq int = y + 1
+						if eq < z && '=' == this.content[eq] {
+							y = eq + 1
 							if '"' == this.content[y] {
-								y = span.Forward(this.content,y,z,'"','"')
+								y = span.Forward(this.content,(y+1),z,'"','"')
 								if 0 < y {
-									var at_be, at_en int = x, (y+1)
+									var at_be, at_en int = x, (y+2)
 									var atx Text = this.content[at_be:at_en]
 
 									var at Attribute
@@ -413,9 +377,9 @@ func (this Element) Read(url string, content Text) (n Node, er error) {
 									break
 								}
 							} else if '\'' == this.content[y] {
-								y = span.Forward(this.content,y,z,'\'','\'')
+								y = span.Forward(this.content,(y+1),z,'\'','\'')
 								if 0 < y {
-									var at_be, at_en int = x, (y+1)
+									var at_be, at_en int = x, (y+2)
 									var atx Text = this.content[at_be:at_en]
 
 									var at Attribute
@@ -475,6 +439,15 @@ func (this Element) Read(url string, content Text) (n Node, er error) {
 			}
 		}
 	}
+	/*
+	 * Record this element's own "xmlns"/"xmlns:prefix" declarations.
+	 * They are only this element's own bindings at this point;
+	 * AppendChild merges them with whatever an ancestor declares
+	 * (propagateNamespaces) once "this" is actually placed in a
+	 * tree, and rebinds attribute owners to match.
+	 */
+	this.bindDeclaredNamespaces()
+	this.rebindAttributeOwners()
 	/*
 	 * Element content [TODO] (review)
 	 */
@@ -513,6 +486,7 @@ func (this Element) Read(url string, content Text) (n Node, er error) {
 									return this, er
 								} else {
 									el = n.(Element)
+									el.parent = this
 
 									this.children = append(this.children,el)
 								}
@@ -529,6 +503,7 @@ func (this Element) Read(url string, content Text) (n Node, er error) {
 									return this, er
 								} else {
 									el = n.(Element)
+									el.parent = this
 
 									this.children = append(this.children,el)
 
@@ -608,12 +583,23 @@ func (this Attribute) Read(url string, content Text) (n Node, er error) {
 			}
 		} else {
 			y = span.Class(this.content,x,z,span.XI)
-			if 0 < y {
+			if -1 != y {
 				y += 1
 				if y < z {
 					if '=' == this.content[y] {
 						this.name = string(this.content[x:y])
-						this.value = string(this.content[y+1])
+
+						var vx int = y + 1
+						if vx < (z-1) && ('"' == this.content[vx] || '\'' == this.content[vx]) {
+							var quote byte = this.content[vx]
+							if quote == this.content[z-1] {
+								this.value = string(this.content[vx+1 : z-1])
+							} else {
+								return this, fmt.Errorf("Attribute quote missing in '%s'.",content)
+							}
+						} else {
+							this.value = string(this.content[vx:])
+						}
 					} else {
 						return this, fmt.Errorf("Attribute syntax of content '%s'.",content)
 					}
@@ -638,7 +624,7 @@ func (this Text) KindOf() (Kind){
 			if '<' == this[x] && '>' == this[y] {
 				x += 1
 				y -= 1
-				if x < y {
+				if x <= y {
 					switch this[x] {
 
 					case '?':